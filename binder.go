@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/go-mego/mego"
+)
+
+// Binder 是一個能夠將請求資料映射至指針建構體的介面，
+// 透過實作此介面即可讓應用程式以自訂的方式取代或擴充內建的映射行為
+// （例如替換為串流式 JSON 映射、具有綱要驗證的映射，或是其他 `New` 未內建支援的格式）。
+type Binder interface {
+	// Bind 會將 `c` 所帶有的請求資料映射至 `dest` 型態的複製體指針並回傳，
+	// 若映射過程中發生錯誤則會回傳該錯誤。
+	Bind(dest interface{}, c *mego.Context) (interface{}, error)
+}
+
+// DefaultBinder 實作了 `Binder` 介面，這是 `New` 預設使用的映射行為：
+// 依照請求的 `Content-Type` 自動選擇對應的 `Binder` 來進行映射。
+type DefaultBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (DefaultBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	binder, ok := lookupBinder(c.ContentType())
+	if !ok {
+		return nil, ErrUnsupportedMediaType
+	}
+	return binder.Bind(dest, c)
+}
+
+// lookupBinder 會依照 `contentType` 的前綴在 `registry` 中尋找對應的 `Binder`，
+// 讓 `DefaultBinder.Bind` 不需要直接操作 `registry` 與其鎖定邏輯。
+func lookupBinder(contentType string) (Binder, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, entry := range registry {
+		if strings.HasPrefix(contentType, entry.mime) {
+			return entry.binder, true
+		}
+	}
+	return nil, false
+}
+
+// binderEntry 是 MIME 種類與其對應 `Binder` 的配對，`registry` 會依照加入順序來比對請求的 `Content-Type`。
+type binderEntry struct {
+	mime   string
+	binder Binder
+}
+
+var (
+	// registryMu 用於保護 `registry` 不會在 `RegisterBinder` 與 `DefaultBinder.Bind` 同時執行時發生資料競爭。
+	registryMu sync.RWMutex
+	// registry 是 `DefaultBinder` 用來依照 `Content-Type` 選擇 `Binder` 的對照表。
+	registry = []binderEntry{
+		{MIMEApplicationJSON, JSONBinder{}},
+		{MIMEApplicationXML, XMLBinder{}},
+		{MIMETextXML, XMLBinder{}},
+		{MIMEApplicationYAML, YAMLBinder{}},
+		{MIMEApplicationTOML, TOMLBinder{}},
+		{MIMEApplicationProtobuf, ProtobufBinder{}},
+		{MIMEApplicationMsgPack, MsgPackBinder{}},
+		{MIMEMultipartForm, FormBinder{}},
+		{MIMEApplicationForm, FormBinder{}},
+	}
+	// defaultBinderMu 用於保護 `defaultBinder` 不會在 `SetDefault` 與 `New` 同時執行時發生資料競爭。
+	defaultBinderMu sync.RWMutex
+	// defaultBinder 是 `New` 目前所使用的 `Binder`，可以透過 `SetDefault` 來替換。
+	defaultBinder Binder = DefaultBinder{}
+)
+
+// SetDefault 能夠將 `New` 所使用的預設 `Binder` 替換為 `b`，
+// 讓應用程式可以整體性地改變自動映射的行為，而不需要替每個路由個別指定。
+func SetDefault(b Binder) {
+	defaultBinderMu.Lock()
+	defer defaultBinderMu.Unlock()
+
+	defaultBinder = b
+}
+
+// getDefault 會以併發安全的方式取得目前的預設 `Binder`。
+func getDefault() Binder {
+	defaultBinderMu.RLock()
+	defer defaultBinderMu.RUnlock()
+
+	return defaultBinder
+}
+
+// RegisterBinder 能夠註冊或覆蓋一個 MIME 種類所對應的 `Binder`，
+// 讓 `DefaultBinder`（以及任何委派給 `registry` 的 `Binder`）在遇到符合該 MIME 種類前綴的請求時改用 `b` 進行映射。
+func RegisterBinder(mime string, b Binder) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for i := range registry {
+		if registry[i].mime == mime {
+			registry[i].binder = b
+			return
+		}
+	}
+	registry = append(registry, binderEntry{mime, b})
+}
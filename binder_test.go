@@ -0,0 +1,113 @@
+package binding
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-mego/mego"
+)
+
+// stubBinder 是一個僅用於測試 `RegisterBinder`/`SetDefault` 分派邏輯的 `Binder`，
+// 它不需要讀取 `c` 就能回傳固定結果，因此測試不需要建立完整的 `mego.Context`。
+type stubBinder struct{ tag string }
+
+func (b stubBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return b.tag, nil
+}
+
+func TestLookupBinderFindsRegisteredMime(t *testing.T) {
+	const mime = "application/x-binder-test"
+	RegisterBinder(mime, stubBinder{tag: "registered"})
+
+	binder, ok := lookupBinder(mime + "; charset=utf-8")
+	if !ok {
+		t.Fatalf("expected a binder to be found for %q", mime)
+	}
+	ptr, err := binder.Bind(nil, nil)
+	if err != nil || ptr != "registered" {
+		t.Fatalf("unexpected bind result: ptr=%v err=%v", ptr, err)
+	}
+}
+
+func TestLookupBinderOverridesExistingMime(t *testing.T) {
+	RegisterBinder(MIMEApplicationJSON, stubBinder{tag: "overridden"})
+	defer RegisterBinder(MIMEApplicationJSON, JSONBinder{})
+
+	binder, ok := lookupBinder(MIMEApplicationJSON)
+	if !ok {
+		t.Fatalf("expected a binder to be found for %q", MIMEApplicationJSON)
+	}
+	ptr, _ := binder.Bind(nil, nil)
+	if ptr != "overridden" {
+		t.Fatalf("expected the overriding binder to be used, got %v", ptr)
+	}
+}
+
+func TestLookupBinderUnsupportedMime(t *testing.T) {
+	if _, ok := lookupBinder("application/does-not-exist"); ok {
+		t.Fatalf("expected no binder to be found for an unregistered mime")
+	}
+}
+
+func TestDefaultBinderBindDispatchesByContentType(t *testing.T) {
+	// 這裡透過真正的 `*mego.Context`（帶有 `Content-Type` 標頭）呼叫 `DefaultBinder{}.Bind`，
+	// 驗證 `c.ContentType()` → `lookupBinder` → 選中 `Binder.Bind` 這整條分派路徑，
+	// 而不只是驗證 `lookupBinder` 本身在給定字串下的回傳結果。
+	const mime = "application/x-default-binder-test"
+	RegisterBinder(mime, stubBinder{tag: "dispatched"})
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Content-Type", mime)
+	c := &mego.Context{Request: req}
+
+	ptr, err := DefaultBinder{}.Bind(nil, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ptr != "dispatched" {
+		t.Fatalf("ptr = %v, want %q", ptr, "dispatched")
+	}
+}
+
+func TestDefaultBinderBindUnsupportedContentType(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Content-Type", "application/does-not-exist")
+	c := &mego.Context{Request: req}
+
+	_, err := DefaultBinder{}.Bind(nil, c)
+	if err != ErrUnsupportedMediaType {
+		t.Fatalf("expected ErrUnsupportedMediaType, got %v", err)
+	}
+}
+
+func TestDefaultBinderBindDispatchesFormContentType(t *testing.T) {
+	// 和上面兩個測試不同，這裡分派到真正內建的 `FormBinder`，
+	// 端對端驗證 `DefaultBinder` 在實際請求下會選中並執行正確的映射行為。
+	type formPayload struct {
+		Name string `form:"name"`
+	}
+	req := httptest.NewRequest("POST", "/", strings.NewReader(url.Values{"name": {"alice"}}.Encode()))
+	req.Header.Set("Content-Type", MIMEApplicationForm)
+	c := &mego.Context{Request: req}
+
+	ptr, err := DefaultBinder{}.Bind(formPayload{}, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ptr.(*formPayload).Name; got != "alice" {
+		t.Fatalf("Name = %q, want %q", got, "alice")
+	}
+}
+
+func TestSetDefaultReplacesDefaultBinder(t *testing.T) {
+	original := getDefault()
+	defer SetDefault(original)
+
+	SetDefault(stubBinder{tag: "custom-default"})
+	ptr, _ := getDefault().Bind(nil, nil)
+	if ptr != "custom-default" {
+		t.Fatalf("expected getDefault to return the binder set via SetDefault, got %v", ptr)
+	}
+}
@@ -3,8 +3,8 @@ package binding
 import (
 	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http"
-	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -28,12 +28,26 @@ const (
 	MIMEApplicationForm = "application/x-www-form-urlencoded"
 	// MIMEApplicationJSON 是 JSON 的請求 MIME 種類。
 	MIMEApplicationJSON = "application/json"
+	// MIMEApplicationXML 是 XML 的請求 MIME 種類。
+	MIMEApplicationXML = "application/xml"
+	// MIMETextXML 是 XML 的另一種請求 MIME 種類。
+	MIMETextXML = "text/xml"
+	// MIMEApplicationYAML 是 YAML 的請求 MIME 種類。
+	MIMEApplicationYAML = "application/x-yaml"
+	// MIMEApplicationTOML 是 TOML 的請求 MIME 種類。
+	MIMEApplicationTOML = "application/toml"
+	// MIMEApplicationProtobuf 是 Protobuf 的請求 MIME 種類。
+	MIMEApplicationProtobuf = "application/x-protobuf"
+	// MIMEApplicationMsgPack 是 MessagePack 的請求 MIME 種類。
+	MIMEApplicationMsgPack = "application/x-msgpack"
 	// MIMEMultipartForm 是基本表單的請求 MIME 種類。
 	MIMEMultipartForm = "multipart/form-data"
 	// 欄位標籤名稱。
-	fieldTagJSON    = "json"
 	fieldTagForm    = "form"
 	fieldTagQuery   = "query"
+	fieldTagURI     = "uri"
+	fieldTagParam   = "param"
+	fieldTagHeader  = "header"
 	fieldTagBinding = "binding"
 )
 
@@ -45,82 +59,175 @@ type BindUnmarshaler interface {
 // New 會接收一個指針建構體，並且初始化自動映射模組。
 // 這會在接收請求時自動識別請求型態，並將請求內容映射至指針建構體，
 // 接著就能夠在路由處理函式中直接透過參數使用已映射的建構體資料。
+//
+// 實際負責判別請求型態的是目前透過 `SetDefault` 設置的預設 `Binder`，
+// 預設情況下為 `DefaultBinder`。
 func New(dest interface{}) mego.HandlerFunc {
 	return func(c *mego.Context) {
-		switch {
-		case strings.HasPrefix(c.ContentType(), MIMEApplicationJSON):
-			NewJSON(dest)(c)
-		case strings.HasPrefix(c.ContentType(), MIMEMultipartForm):
-			NewForm(dest)(c)
-		case strings.HasPrefix(c.ContentType(), MIMEApplicationForm):
-			NewForm(dest)(c)
-		default:
-			c.AbortWithError(http.StatusInternalServerError, ErrUnsupportedMediaType)
-		}
+		ptr, err := getDefault().Bind(dest, c)
+		abortOrMap(c, ptr, err)
 	}
 }
 
+// JSONBinder 會以 JSON 方式映射請求資料，並實作了 `Binder` 介面。
+// 這會直接將請求主體反序列化至建構體指針，因此巢狀或具有型態的欄位也能正確映射。
+type JSONBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (JSONBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return rawBodyBind(c, dest, json.Unmarshal)
+}
+
 // NewJSON 和 `New` 相同，但這並不會自動判別請求型態，
 // 而是強迫以 JSON 方式來映射請求資料。
 func NewJSON(dest interface{}) mego.HandlerFunc {
 	return func(c *mego.Context) {
-		data, err := c.GetRawData()
-		if err != nil {
-			c.AbortWithError(http.StatusBadRequest, err)
-			return
-		}
-		var v url.Values
-		err = json.Unmarshal(data, &v)
-		if err != nil {
-			c.AbortWithError(http.StatusBadRequest, err)
-			return
-		}
-		ptr, err := Bind(dest, v, fieldTagJSON)
-		if err != nil {
-			c.AbortWithError(http.StatusBadRequest, err)
-			return
-		}
-		c.Map(ptr)
+		ptr, err := JSONBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
 	}
 }
 
+// QueryBinder 會以網址參數（URL Query）方式映射請求資料，並實作了 `Binder` 介面。
+type QueryBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (QueryBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return Bind(dest, c.Request.URL.Query(), fieldTagForm)
+}
+
 // NewQuery 和 `New` 相同，但這並不會自動判別請求型態，
 // 而是強迫以網址參數（URL Query）方式來映射請求資料。
 func NewQuery(dest interface{}) mego.HandlerFunc {
 	return func(c *mego.Context) {
-		ptr, err := Bind(dest, c.Request.URL.Query(), fieldTagForm)
-		if err != nil {
-			c.AbortWithError(http.StatusBadRequest, err)
-			return
+		ptr, err := QueryBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// URIBinder 會以路由中的路徑參數映射請求資料，並實作了 `Binder` 介面。
+type URIBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (URIBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	data := make(map[string][]string, len(c.Params))
+	for _, p := range c.Params {
+		data[p.Key] = []string{p.Value}
+	}
+	return Bind(dest, data, fieldTagURI)
+}
+
+// NewURI 和 `New` 相同，但這並不會自動判別請求型態，
+// 而是強迫以路由中的路徑參數（例如 `/users/:name`）來映射請求資料，
+// 並且透過 `uri` 標籤（或是 `param`）指定對應的路徑參數名稱。
+func NewURI(dest interface{}) mego.HandlerFunc {
+	return func(c *mego.Context) {
+		ptr, err := URIBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// HeaderBinder 會以請求標頭映射請求資料，並實作了 `Binder` 介面。
+type HeaderBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (HeaderBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return Bind(dest, c.Request.Header, fieldTagHeader)
+}
+
+// NewHeader 和 `New` 相同，但這並不會自動判別請求型態，
+// 而是強迫以請求標頭（`c.Request.Header`）來映射請求資料，
+// 並且透過 `header` 標籤指定對應的標頭名稱。
+func NewHeader(dest interface{}) mego.HandlerFunc {
+	return func(c *mego.Context) {
+		ptr, err := HeaderBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// FormBinder 會以標準表單或網址表單方式映射請求資料，並實作了 `Binder` 介面。
+// 當請求為 `multipart/form-data` 時，這也會一併映射 `*multipart.FileHeader`
+// 與 `[]*multipart.FileHeader` 型態的欄位，讓上傳的檔案能夠直接映射至建構體。
+type FormBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (FormBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	var files map[string][]*multipart.FileHeader
+	if strings.HasPrefix(c.ContentType(), MIMEMultipartForm) {
+		if err := c.Request.ParseMultipartForm(defaultMemory); err != nil {
+			return nil, err
+		}
+		if c.Request.MultipartForm != nil {
+			files = c.Request.MultipartForm.File
+		}
+	} else {
+		if err := c.Request.ParseForm(); err != nil {
+			return nil, err
 		}
-		c.Map(ptr)
 	}
+
+	ptr := reflect.New(reflect.TypeOf(dest)).Interface()
+	if err := bindToPtr(ptr, c.Request.Form, files, fieldTagForm); err != nil {
+		return nil, err
+	}
+	return ptr, nil
 }
 
 // NewForm 和 `New` 相同，但這並不會自動判別請求型態，
 // 而是強迫以標準表單（Form Data）或是網址表單（URL Encoded）方式來映射請求資料。
 func NewForm(dest interface{}) mego.HandlerFunc {
 	return func(c *mego.Context) {
-		if strings.HasPrefix(c.ContentType(), MIMEMultipartForm) {
-			err := c.Request.ParseMultipartForm(defaultMemory)
-			if err != nil {
-				c.AbortWithError(http.StatusBadRequest, err)
-				return
-			}
-		} else {
-			err := c.Request.ParseForm()
-			if err != nil {
-				c.AbortWithError(http.StatusBadRequest, err)
-				return
-			}
-		}
-		ptr, err := Bind(dest, c.Request.Form, fieldTagForm)
-		if err != nil {
-			c.AbortWithError(http.StatusBadRequest, err)
-			return
+		ptr, err := FormBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// abortOrMap 會依照 `Binder.Bind` 的回傳結果，在發生錯誤時中止請求，否則將映射結果注入給後續處理函式。
+func abortOrMap(c *mego.Context, ptr interface{}, err error) {
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrUnsupportedMediaType) {
+			status = http.StatusInternalServerError
 		}
-		c.Map(ptr)
+		c.AbortWithError(status, wrapBindError(err))
+		return
+	}
+	c.Map(ptr)
+}
+
+// wrapBindError 會將驗證失敗的 `ValidationErrors` 包裝為 `mego.Error` 並標記為 `ErrorTypePrivate`，
+// 避免欄位名稱、規則與內部訊息等細節透過 mego 的錯誤處理中介層直接外洩至回應內容。
+func wrapBindError(err error) error {
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+	return &mego.Error{
+		Err: validationErrs,
+		Meta: mego.H{
+			"fields": validationErrs,
+		},
+		Type: mego.ErrorTypePrivate,
+	}
+}
+
+// rawBodyBind 會讀取請求主體的原始位元組，並透過 `unmarshal` 直接反序列化至建構體複製體的指針，
+// 讓 `JSONBinder`、`XMLBinder` 等以請求主體為來源的 `Binder` 共用同一套讀取流程。
+func rawBodyBind(c *mego.Context, dest interface{}, unmarshal func([]byte, interface{}) error) (interface{}, error) {
+	data, err := c.GetRawData()
+	if err != nil {
+		return nil, err
 	}
+	return decodeBody(data, dest, unmarshal)
+}
+
+// decodeBody 會替 `dest` 建立一份複製體指針，並透過 `unmarshal` 將 `data` 直接反序列化至該指針，
+// 因此巢狀或具有型態的欄位也能正確映射，而不需要像 `BindToPtr` 那樣先轉換為扁平的 `url.Values`。
+func decodeBody(data []byte, dest interface{}, unmarshal func([]byte, interface{}) error) (interface{}, error) {
+	ptr := reflect.New(reflect.TypeOf(dest)).Interface()
+	if err := unmarshal(data, ptr); err != nil {
+		return nil, err
+	}
+	return ptr, nil
 }
 
 // Bind 能夠接收一個目標建構體，並且複製該建構體然後替新的複製體建立指針。
@@ -138,52 +245,126 @@ func Bind(ptr interface{}, data map[string][]string, tag string) (interface{}, e
 
 // BindToPtr 能夠接收一個目標建構體指針，並將 `map` 資料映射至該建構體中，
 // 且可以透過指定建構體欄位標籤來作為映射欄位的依據。
+//
+// `data` 的鍵可以使用中括號記法（例如 `user[name]`、`tags[0]`）來表示巢狀建構體、
+// 切片或映射欄位，這會先由 `buildKeyTree` 解析成樹狀結構後再依序走訪比對。
+// 為了減少熱路徑上的反射成本，欄位資訊會透過 `cachedFields` 快取。
+// 若有欄位未能通過 `binding` 標籤所描述的驗證規則，會在走訪完所有欄位後一次回傳 `ValidationErrors`，
+// 而不會在遇到第一個錯誤時就中止映射。
 func BindToPtr(ptr interface{}, data map[string][]string, tag string) error {
-	data = convertKeys(data)
-	typ := reflect.TypeOf(ptr).Elem()
-	val := reflect.ValueOf(ptr).Elem()
+	return bindToPtr(ptr, data, nil, tag)
+}
 
+// bindToPtr 和 `BindToPtr` 相同，但多接收一個上傳檔案的對照表，
+// 讓 `FormBinder` 能夠一併映射 `*multipart.FileHeader` 欄位。
+func bindToPtr(ptr interface{}, data map[string][]string, files map[string][]*multipart.FileHeader, tag string) error {
+	typ := reflect.TypeOf(ptr).Elem()
 	if typ.Kind() != reflect.Struct {
 		return ErrNotStruct
 	}
 
-	for i := 0; i < typ.NumField(); i++ {
-		typeField := typ.Field(i)
-		structField := val.Field(i)
-		if !structField.CanSet() {
+	tree := buildKeyTree(data)
+	defer releaseKeyTree(tree)
+	convertedFiles := convertFileKeys(files)
+
+	var errs ValidationErrors
+	if err := bindStruct(reflect.ValueOf(ptr).Elem(), typ, tree, convertedFiles, tag, &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// bindStruct 會依照快取的欄位計劃，將 `node` 樹狀結構中對應的資料映射至建構體值，
+// 並在遇到巢狀建構體、索引式切片（`tags[0]`）或映射欄位（`map[string]T`）時遞迴或特別處理。
+// 驗證規則的失敗結果會附加至 `errs`，讓呼叫者能夠在走訪完畢後取得完整的錯誤清單。
+func bindStruct(val reflect.Value, typ reflect.Type, node *keyNode, files map[string][]*multipart.FileHeader, tag string, errs *ValidationErrors) error {
+	plan := cachedFields(typ)
+
+	for i := range plan.fields {
+		field := &plan.fields[i]
+		structField := val.Field(field.index)
+
+		raw := field.tags[tag]
+		if raw == "" && tag == fieldTagURI {
+			raw = field.tags[fieldTagParam]
+		}
+		if raw == "-" {
 			continue
 		}
-		structFieldKind := structField.Kind()
-		inputFieldName := typeField.Tag.Get(tag)
-		bindingFieldName := typeField.Tag.Get(fieldTagBinding)
-		if inputFieldName == "-" {
+
+		if field.fileHeader || field.fileHeaderSlice {
+			inputFieldName, _ := field.resolveName(tag)
+			headers, exists := files[normalizeKey(inputFieldName)]
+			if !exists {
+				runRequiredRule(structField, field, inputFieldName, errs)
+				continue
+			}
+			bindFileHeaderField(field, headers, structField)
+			runRules(structField, field, inputFieldName, errs)
 			continue
 		}
-		if inputFieldName == "" {
-			inputFieldName = typeField.Name
-			inputFieldName = strings.ToLower(inputFieldName)
-			// If tag is nil, we inspect if the field is a struct.
-			if _, ok := bindUnmarshaler(structField); !ok && structFieldKind == reflect.Struct {
-				err := BindToPtr(structField.Addr().Interface(), data, tag)
-				if err != nil {
+
+		child := matchChild(node, field, tag)
+
+		if field.isMap {
+			name, _ := field.resolveName(tag)
+			if child == nil || len(child.children) == 0 {
+				runRequiredRule(structField, field, name, errs)
+				continue
+			}
+			if err := bindMapField(child, structField); err != nil {
+				return err
+			}
+			runRules(structField, field, name, errs)
+			continue
+		}
+
+		if field.dive {
+			if child != nil && len(child.children) > 0 {
+				if err := bindStruct(structField, field.sub.typ, child, files, tag, errs); err != nil {
 					return err
 				}
-				continue
+			} else if err := bindStruct(structField, field.sub.typ, node, files, tag, errs); err != nil {
+				return err
 			}
+			continue
 		}
-		inputValue, exists := data[inputFieldName]
-		if !exists {
+
+		name, _ := field.resolveName(tag)
+
+		if child == nil {
+			runRequiredRule(structField, field, name, errs)
 			continue
 		}
-		// Call this first, in case we're dealing with an alias to an array type
-		if ok, err := unmarshalField(typeField.Type.Kind(), inputValue[0], structField); ok {
-			if err != nil {
-				return err
+
+		inputValue := child.value
+		if len(inputValue) == 0 && field.kind == reflect.Slice && len(child.children) > 0 {
+			for _, sub := range orderedIndexedChildren(child) {
+				if len(sub.value) > 0 {
+					inputValue = append(inputValue, sub.value[0])
+				}
 			}
+		}
+		if len(inputValue) == 0 {
+			runRequiredRule(structField, field, name, errs)
 			continue
 		}
+
+		// Call this first, in case we're dealing with an alias to an array type
+		if field.unmarshaler {
+			if ok, err := unmarshalField(field.kind, inputValue[0], structField); ok {
+				if err != nil {
+					return err
+				}
+				runRules(structField, field, name, errs)
+				continue
+			}
+		}
 		numElems := len(inputValue)
-		if structFieldKind == reflect.Slice && numElems > 0 {
+		if field.kind == reflect.Slice && numElems > 0 {
 			sliceOf := structField.Type().Elem().Kind()
 			slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
 			for j := 0; j < numElems; j++ {
@@ -191,42 +372,65 @@ func BindToPtr(ptr interface{}, data map[string][]string, tag string) error {
 					return err
 				}
 			}
-			val.Field(i).Set(slice)
+			structField.Set(slice)
 		} else {
-			if err := setWithProperType(typeField.Type.Kind(), inputValue[0], structField); err != nil {
+			if err := setWithProperType(field.kind, inputValue[0], structField); err != nil {
 				return err
 			}
 		}
-		if structField.CanInterface() {
-			if bindingFieldName == "required" {
-				if isZeroOfUnderlyingType(structField.Interface()) {
-					return &mego.Error{
-						Err: ErrRequired,
-						Meta: mego.H{
-							"field": inputFieldName,
-						},
-						Type: mego.ErrorTypePrivate,
-					}
-				}
-			}
+		runRules(structField, field, name, errs)
+	}
+	return nil
+}
+
+// bindMapField 會將 `node` 底下所有子節點填入 `map[string]T` 型態的欄位，
+// 每個子節點的鍵即為映射的鍵，其值則會依照映射的元素型態轉換。
+func bindMapField(node *keyNode, structField reflect.Value) error {
+	elemType := structField.Type().Elem()
+	mapValue := reflect.MakeMapWithSize(structField.Type(), len(node.children))
+	for key, sub := range node.children {
+		if len(sub.value) == 0 {
+			continue
 		}
+		elem := reflect.New(elemType).Elem()
+		if err := setWithProperType(elemType.Kind(), sub.value[0], elem); err != nil {
+			return err
+		}
+		mapValue.SetMapIndex(reflect.ValueOf(key), elem)
 	}
+	structField.Set(mapValue)
 	return nil
 }
 
-// convertKeys 能夠移除表單欄位名稱中的分隔符號，並且全部改為小寫來讓映射時能夠完好地對應本地建構體欄位。
-func convertKeys(source map[string][]string) map[string][]string {
-	dest := make(map[string][]string)
+// convertFileKeys 會以和 `matchChild` 相同的 `normalizeKey` 規則正規化上傳檔案的欄位名稱，
+// 讓 `*multipart.FileHeader` 欄位也能夠使用與其他表單欄位相同的比對方式。
+func convertFileKeys(source map[string][]*multipart.FileHeader) map[string][]*multipart.FileHeader {
+	if len(source) == 0 {
+		return nil
+	}
+	dest := make(map[string][]*multipart.FileHeader, len(source))
 	for k, v := range source {
-		o := k
-		o = strings.Replace(o, "_", "", -1)
-		o = strings.Replace(o, "-", "", -1)
-		o = strings.ToLower(o)
-		dest[o] = v
+		dest[normalizeKey(k)] = v
 	}
 	return dest
 }
 
+// bindFileHeaderField 會將上傳檔案的標頭設置至 `*multipart.FileHeader`
+// 或 `[]*multipart.FileHeader` 型態的欄位。
+func bindFileHeaderField(field *fieldPlan, headers []*multipart.FileHeader, structField reflect.Value) {
+	if field.fileHeaderSlice {
+		slice := reflect.MakeSlice(structField.Type(), len(headers), len(headers))
+		for i, h := range headers {
+			slice.Index(i).Set(reflect.ValueOf(h))
+		}
+		structField.Set(slice)
+		return
+	}
+	if len(headers) > 0 {
+		structField.Set(reflect.ValueOf(headers[0]))
+	}
+}
+
 // isZeroOfUnderlyingType 會表示一個 `interface{}` 值的底層是不是零值。
 func isZeroOfUnderlyingType(v interface{}) bool {
 	return reflect.DeepEqual(v, reflect.Zero(reflect.TypeOf(v)).Interface())
@@ -0,0 +1,131 @@
+package binding
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-mego/mego"
+)
+
+type uriPayload struct {
+	Name string `uri:"name"`
+	ID   string `param:"id"`
+}
+
+func TestURIBinderUsesUriTag(t *testing.T) {
+	// URIBinder.Bind 會把 `c.Params` 轉換成單值的 `map[string][]string` 後交給 Bind，
+	// 這裡直接模擬該轉換結果，驗證 `uri` 標籤能正確對應路徑參數。
+	ptr, err := Bind(uriPayload{}, map[string][]string{"name": {"alice"}, "id": {"42"}}, fieldTagURI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ptr.(*uriPayload)
+	if got.Name != "alice" {
+		t.Errorf("Name = %q, want %q", got.Name, "alice")
+	}
+	if got.ID != "42" {
+		t.Errorf("ID = %q, want %q", got.ID, "42")
+	}
+}
+
+func TestURIBinderFallsBackToParamTag(t *testing.T) {
+	type payload struct {
+		Slug string `param:"slug"`
+	}
+	ptr, err := Bind(payload{}, map[string][]string{"slug": {"hello-world"}}, fieldTagURI)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ptr.(*payload).Slug; got != "hello-world" {
+		t.Fatalf("Slug = %q, want %q", got, "hello-world")
+	}
+}
+
+func TestURIBinderBindConvertsContextParams(t *testing.T) {
+	// 這裡透過真正的 `*mego.Context` 呼叫 `URIBinder{}.Bind`，
+	// 驗證它把 `c.Params` 轉換成 `map[string][]string` 的轉換邏輯本身（而不只是轉換後交給 `Bind` 的結果）。
+	c := &mego.Context{
+		Request: httptest.NewRequest("GET", "/users/alice/42", nil),
+		Params: mego.Params{
+			{Key: "name", Value: "alice"},
+			{Key: "id", Value: "42"},
+		},
+	}
+	ptr, err := URIBinder{}.Bind(uriPayload{}, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ptr.(*uriPayload)
+	if got.Name != "alice" {
+		t.Errorf("Name = %q, want %q", got.Name, "alice")
+	}
+	if got.ID != "42" {
+		t.Errorf("ID = %q, want %q", got.ID, "42")
+	}
+}
+
+func TestURIBinderErrorsOnMissingRequiredParam(t *testing.T) {
+	type payload struct {
+		Name string `uri:"name" binding:"required"`
+	}
+	c := &mego.Context{Request: httptest.NewRequest("GET", "/users/", nil)}
+	_, err := URIBinder{}.Bind(payload{}, c)
+	if err == nil {
+		t.Fatalf("expected an error when the route carries no params")
+	}
+}
+
+type headerPayload struct {
+	RequestID string `header:"X-Request-Id"`
+}
+
+func TestHeaderBinderUsesHeaderTag(t *testing.T) {
+	// HeaderBinder.Bind 直接把 `c.Request.Header`（一個 `map[string][]string`）交給 Bind，
+	// 這裡以同樣形狀的 map 驗證 `header` 標籤能正確對應標頭欄位。
+	ptr, err := Bind(headerPayload{}, map[string][]string{"X-Request-Id": {"req-1"}}, fieldTagHeader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ptr.(*headerPayload).RequestID; got != "req-1" {
+		t.Fatalf("RequestID = %q, want %q", got, "req-1")
+	}
+}
+
+func TestHeaderBinderBindReadsRequestHeader(t *testing.T) {
+	// 這裡透過真正的 `*mego.Context` 呼叫 `HeaderBinder{}.Bind`，
+	// 驗證它把 `c.Request.Header` 直接交給 `Bind` 這件事本身有被執行到，而不只是驗證結果形狀相同的 map。
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-Id", "req-1")
+	c := &mego.Context{Request: req}
+
+	ptr, err := HeaderBinder{}.Bind(headerPayload{}, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ptr.(*headerPayload).RequestID; got != "req-1" {
+		t.Fatalf("RequestID = %q, want %q", got, "req-1")
+	}
+}
+
+func TestHeaderBinderRequiredFieldAbsent(t *testing.T) {
+	type payload struct {
+		RequestID string `header:"X-Request-Id" binding:"required"`
+	}
+	_, err := Bind(payload{}, map[string][]string{}, fieldTagHeader)
+	if err == nil {
+		t.Fatalf("expected an error, got nil")
+	}
+}
+
+func TestHeaderBinderFallsBackToFieldNameWithoutTag(t *testing.T) {
+	type payload struct {
+		Auth string
+	}
+	ptr, err := Bind(payload{}, map[string][]string{"auth": {"bearer token"}}, fieldTagHeader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ptr.(*payload).Auth; got != "bearer token" {
+		t.Fatalf("Auth = %q, want %q", got, "bearer token")
+	}
+}
@@ -0,0 +1,124 @@
+package binding
+
+import (
+	"encoding/xml"
+	"errors"
+	"reflect"
+
+	"github.com/BurntSushi/toml"
+	"github.com/golang/protobuf/proto"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v2"
+
+	"github.com/go-mego/mego"
+)
+
+// ErrNotProtoMessage 表示欲映射的目標建構體並未實作 `proto.Message` 介面。
+var ErrNotProtoMessage = errors.New("binding: destination does not implement proto.Message")
+
+// XMLBinder 會以 XML 方式映射請求資料，並實作了 `Binder` 介面。
+type XMLBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (XMLBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return rawBodyBind(c, dest, xml.Unmarshal)
+}
+
+// NewXML 和 `New` 相同，但這並不會自動判別請求型態，
+// 而是強迫以 XML 方式來映射請求資料。
+func NewXML(dest interface{}) mego.HandlerFunc {
+	return func(c *mego.Context) {
+		ptr, err := XMLBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// YAMLBinder 會以 YAML 方式映射請求資料，並實作了 `Binder` 介面。
+type YAMLBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (YAMLBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return rawBodyBind(c, dest, yaml.Unmarshal)
+}
+
+// NewYAML 和 `New` 相同，但這並不會自動判別請求型態，
+// 而是強迫以 YAML 方式來映射請求資料。
+func NewYAML(dest interface{}) mego.HandlerFunc {
+	return func(c *mego.Context) {
+		ptr, err := YAMLBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// TOMLBinder 會以 TOML 方式映射請求資料，並實作了 `Binder` 介面。
+type TOMLBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (TOMLBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return rawBodyBind(c, dest, func(data []byte, v interface{}) error {
+		_, err := toml.Decode(string(data), v)
+		return err
+	})
+}
+
+// NewTOML 和 `New` 相同，但這並不會自動判別請求型態，
+// 而是強迫以 TOML 方式來映射請求資料。
+func NewTOML(dest interface{}) mego.HandlerFunc {
+	return func(c *mego.Context) {
+		ptr, err := TOMLBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// MsgPackBinder 會以 MessagePack 方式映射請求資料，並實作了 `Binder` 介面。
+type MsgPackBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (MsgPackBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	return rawBodyBind(c, dest, msgpack.Unmarshal)
+}
+
+// NewMsgPack 和 `New` 相同，但這並不會自動判別請求型態，
+// 而是強迫以 MessagePack 方式來映射請求資料。
+func NewMsgPack(dest interface{}) mego.HandlerFunc {
+	return func(c *mego.Context) {
+		ptr, err := MsgPackBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
+
+// ProtobufBinder 會以 Protobuf 方式映射請求資料，並實作了 `Binder` 介面。
+// 欲映射的建構體必須實作 `proto.Message` 介面，否則會回傳 `ErrNotProtoMessage`。
+type ProtobufBinder struct{}
+
+// Bind 實作了 `Binder` 介面。
+func (ProtobufBinder) Bind(dest interface{}, c *mego.Context) (interface{}, error) {
+	data, err := c.GetRawData()
+	if err != nil {
+		return nil, err
+	}
+	return decodeProtobuf(data, dest)
+}
+
+// decodeProtobuf 會替 `dest` 建立一份複製體指針，並確認該指針實作 `proto.Message` 介面後
+// 將 `data` 反序列化至其中，否則回傳 `ErrNotProtoMessage`。
+func decodeProtobuf(data []byte, dest interface{}) (interface{}, error) {
+	ptr := reflect.New(reflect.TypeOf(dest)).Interface()
+	msg, ok := ptr.(proto.Message)
+	if !ok {
+		return nil, ErrNotProtoMessage
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return ptr, nil
+}
+
+// NewProtobuf 和 `New` 相同，但這並不會自動判別請求型態，
+// 而是強迫以 Protobuf 方式來映射請求資料。
+func NewProtobuf(dest interface{}) mego.HandlerFunc {
+	return func(c *mego.Context) {
+		ptr, err := ProtobufBinder{}.Bind(dest, c)
+		abortOrMap(c, ptr, err)
+	}
+}
@@ -0,0 +1,95 @@
+package binding
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v2"
+)
+
+type codecAddress struct {
+	City string `json:"city" xml:"city" yaml:"city" toml:"city" msgpack:"city"`
+}
+
+type codecPayload struct {
+	Name    string       `json:"name" xml:"name" yaml:"name" toml:"name" msgpack:"name"`
+	Address codecAddress `json:"address" xml:"address" yaml:"address" toml:"address" msgpack:"address"`
+}
+
+func TestDecodeBodyJSONUnmarshalsNestedTypedPayload(t *testing.T) {
+	// 過去的 `NewJSON` 會把請求主體先反序列化至 `url.Values`，對巢狀或具有型態的欄位一律失效，
+	// 這裡驗證改為直接反序列化至建構體指針後，巢狀欄位能夠正確映射。
+	raw := []byte(`{"name":"alice","address":{"city":"nyc"}}`)
+	ptr, err := decodeBody(raw, codecPayload{}, json.Unmarshal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ptr.(*codecPayload)
+	if got.Name != "alice" || got.Address.City != "nyc" {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestDecodeBodyXML(t *testing.T) {
+	raw := []byte(`<codecPayload><name>bob</name><address><city>sf</city></address></codecPayload>`)
+	ptr, err := decodeBody(raw, codecPayload{}, xml.Unmarshal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ptr.(*codecPayload)
+	if got.Name != "bob" || got.Address.City != "sf" {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestDecodeBodyYAML(t *testing.T) {
+	raw := []byte("name: carol\naddress:\n  city: boston\n")
+	ptr, err := decodeBody(raw, codecPayload{}, yaml.Unmarshal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ptr.(*codecPayload)
+	if got.Name != "carol" || got.Address.City != "boston" {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestDecodeBodyTOML(t *testing.T) {
+	raw := []byte("name = \"dave\"\n[address]\ncity = \"denver\"\n")
+	ptr, err := decodeBody(raw, codecPayload{}, func(data []byte, v interface{}) error {
+		_, err := toml.Decode(string(data), v)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ptr.(*codecPayload)
+	if got.Name != "dave" || got.Address.City != "denver" {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestDecodeBodyMsgPack(t *testing.T) {
+	raw, err := msgpack.Marshal(codecPayload{Name: "erin", Address: codecAddress{City: "miami"}})
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	ptr, err := decodeBody(raw, codecPayload{}, msgpack.Unmarshal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ptr.(*codecPayload)
+	if got.Name != "erin" || got.Address.City != "miami" {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestDecodeProtobufRejectsNonProtoMessage(t *testing.T) {
+	_, err := decodeProtobuf([]byte{}, codecPayload{})
+	if err != ErrNotProtoMessage {
+		t.Fatalf("expected ErrNotProtoMessage, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-mego/binding"
+	"github.com/go-mego/mego"
+)
+
+type Path struct {
+	Name string `uri:"name"`
+}
+
+type Header struct {
+	UserAgent string
+}
+
+func main() {
+	e := mego.Default()
+	e.GET("/users/:name", binding.NewURI(Path{}), binding.NewHeader(Header{}), func(c *mego.Context, p *Path, h *Header) {
+		c.String(http.StatusOK, "Path: %+v\nHeader: %+v", p, h)
+	})
+	e.Run()
+}
@@ -0,0 +1,156 @@
+package binding
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// keyNode 是表單資料鍵值解析後的巢狀樹狀結構。
+// 帶有中括號記法的鍵（例如 `user[address][city]`、`tags[0]`）會被拆解成一連串路徑區段，
+// 讓 `bindStruct` 能夠依序比對至巢狀建構體、切片或映射欄位，而不需要再依賴單一層級的扁平 `map`。
+type keyNode struct {
+	// value 是直接對應至此節點的資料值，僅在此節點為葉節點時才有意義。
+	value []string
+	// children 是此節點底下依照路徑區段索引的子節點。
+	children map[string]*keyNode
+}
+
+// child 會取得（並於必要時建立）指定路徑區段所對應的子節點。
+func (n *keyNode) child(segment string) *keyNode {
+	if n.children == nil {
+		n.children = make(map[string]*keyNode)
+	}
+	c, ok := n.children[segment]
+	if !ok {
+		c = acquireKeyNode()
+		n.children[segment] = c
+	}
+	return c
+}
+
+// keyNodePool 重複利用 `buildKeyTree` 所建立的樹狀節點，避免每次映射請求都重新配置。
+var keyNodePool = sync.Pool{
+	New: func() interface{} { return &keyNode{} },
+}
+
+// acquireKeyNode 會向 `keyNodePool` 借用一個乾淨的節點。
+func acquireKeyNode() *keyNode {
+	return keyNodePool.Get().(*keyNode)
+}
+
+// releaseKeyTree 會遞迴釋放 `buildKeyTree` 所建立的整棵樹，並將每個節點歸還至 `keyNodePool`。
+func releaseKeyTree(n *keyNode) {
+	if n == nil {
+		return
+	}
+	for key, child := range n.children {
+		releaseKeyTree(child)
+		delete(n.children, key)
+	}
+	n.value = nil
+	keyNodePool.Put(n)
+}
+
+// buildKeyTree 會將表單資料的鍵值解析成巢狀樹狀結構，
+// 讓 `user[name]=alice&user[tags][0]=x&user[tags][1]=y` 這樣的鍵能夠依序比對至巢狀欄位。
+// 回傳的樹狀結構是向 `keyNodePool` 借用的，使用完畢後應該呼叫 `releaseKeyTree` 歸還。
+func buildKeyTree(data map[string][]string) *keyNode {
+	root := acquireKeyNode()
+	for key, values := range data {
+		segments := parseFormKey(key)
+		node := root
+		for _, segment := range segments {
+			node = node.child(segment)
+		}
+		node.value = values
+	}
+	return root
+}
+
+// parseFormKey 會將 `user[tags][0]` 這樣的鍵解析成 `["user", "tags", "0"]` 的路徑區段，
+// 沒有使用中括號記法的鍵則會直接回傳單一區段。
+func parseFormKey(key string) []string {
+	if !strings.ContainsAny(key, "[]") {
+		return []string{key}
+	}
+	var segments []string
+	var current strings.Builder
+	for _, r := range key {
+		switch r {
+		case '[', ']':
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		segments = append(segments, current.String())
+	}
+	return segments
+}
+
+// normalizeKey 會移除鍵值中的 `_`、`-` 分隔符號並轉換為小寫，
+// 讓比對時能夠容忍常見的命名風格差異（例如 `user_name` 對應 `UserName`、
+// `X-Request-Id` 這類經過標準化的標頭鍵對應 `header:"x-request-id"` 標籤）。
+func normalizeKey(key string) string {
+	key = strings.Replace(key, "_", "", -1)
+	key = strings.Replace(key, "-", "", -1)
+	return strings.ToLower(key)
+}
+
+// matchChild 會依照欄位所指定的標籤（`tag`）在 `node` 的子節點中尋找對應的節點，
+// 若該欄位沒有指定標籤，則退而以其欄位名稱比對子節點的鍵。
+// 兩種情況都會先嘗試精確比對，找不到時再以 `normalizeKey` 忽略大小寫與分隔符號的差異比對一次，
+// 讓 `user_name` 之類的鍵仍然能夠映射至未加標籤的 `UserName` 欄位。
+func matchChild(node *keyNode, field *fieldPlan, tag string) *keyNode {
+	if node == nil || len(node.children) == 0 {
+		return nil
+	}
+	raw := field.tags[tag]
+	if raw == "" && tag == fieldTagURI {
+		raw = field.tags[fieldTagParam]
+	}
+	target := raw
+	if target == "" {
+		target = field.fallback
+	}
+	if child, ok := node.children[target]; ok {
+		return child
+	}
+	normalizedTarget := normalizeKey(target)
+	for segment, child := range node.children {
+		if normalizeKey(segment) == normalizedTarget {
+			return child
+		}
+	}
+	return nil
+}
+
+// orderedIndexedChildren 會將一個節點底下以數字索引命名的子節點（例如 `tags[0]`、`tags[1]`）
+// 依照索引由小到大排序後回傳，非數字的鍵會被忽略。
+func orderedIndexedChildren(node *keyNode) []*keyNode {
+	type indexed struct {
+		index int
+		node  *keyNode
+	}
+	ordered := make([]indexed, 0, len(node.children))
+	for key, child := range node.children {
+		n, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		ordered = append(ordered, indexed{index: n, node: child})
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].index < ordered[j].index })
+
+	result := make([]*keyNode, len(ordered))
+	for i, o := range ordered {
+		result[i] = o.node
+	}
+	return result
+}
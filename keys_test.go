@@ -0,0 +1,136 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFormKey(t *testing.T) {
+	cases := []struct {
+		key  string
+		want []string
+	}{
+		{"name", []string{"name"}},
+		{"user[name]", []string{"user", "name"}},
+		{"user[address][city]", []string{"user", "address", "city"}},
+		{"tags[0]", []string{"tags", "0"}},
+	}
+	for _, c := range cases {
+		got := parseFormKey(c.key)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseFormKey(%q) = %v, want %v", c.key, got, c.want)
+		}
+	}
+}
+
+func TestBuildKeyTree(t *testing.T) {
+	tree := buildKeyTree(map[string][]string{
+		"user[name]":    {"alice"},
+		"user[tags][0]": {"x"},
+		"user[tags][1]": {"y"},
+	})
+	defer releaseKeyTree(tree)
+
+	user, ok := tree.children["user"]
+	if !ok {
+		t.Fatalf("expected a %q child node", "user")
+	}
+	if name, ok := user.children["name"]; !ok || name.value[0] != "alice" {
+		t.Fatalf("expected user[name] to resolve to %q", "alice")
+	}
+	tags, ok := user.children["tags"]
+	if !ok {
+		t.Fatalf("expected a %q child node", "tags")
+	}
+	ordered := orderedIndexedChildren(tags)
+	if len(ordered) != 2 || ordered[0].value[0] != "x" || ordered[1].value[0] != "y" {
+		t.Fatalf("unexpected ordered indexed children: %+v", ordered)
+	}
+}
+
+func TestMatchChildFallbackIsForgiving(t *testing.T) {
+	tree := buildKeyTree(map[string][]string{
+		"user_name": {"alice"},
+	})
+	defer releaseKeyTree(tree)
+
+	field := &fieldPlan{fallback: "username", tags: map[string]string{fieldTagForm: ""}}
+	child := matchChild(tree, field, fieldTagForm)
+	if child == nil || child.value[0] != "alice" {
+		t.Fatalf("expected untagged field %q to match key %q", "username", "user_name")
+	}
+}
+
+func TestMatchChildTagIsForgiving(t *testing.T) {
+	tree := buildKeyTree(map[string][]string{
+		"X-Request-Id": {"abc"},
+	})
+	defer releaseKeyTree(tree)
+
+	field := &fieldPlan{fallback: "requestid", tags: map[string]string{fieldTagHeader: "x-request-id"}}
+	child := matchChild(tree, field, fieldTagHeader)
+	if child == nil || child.value[0] != "abc" {
+		t.Fatalf("expected header tag %q to match canonicalized key %q", "x-request-id", "X-Request-Id")
+	}
+}
+
+type addressPayload struct {
+	City string `form:"city"`
+}
+
+type userPayload struct {
+	Name    string            `form:"name"`
+	Tags    []string          `form:"tags"`
+	Address addressPayload    `form:"address"`
+	Meta    map[string]string `form:"meta"`
+}
+
+type nestedFormPayload struct {
+	User userPayload `form:"user"`
+}
+
+func TestBindToPtrNestedBracketFormKeysEndToEnd(t *testing.T) {
+	// 端對端驗證 request 中明確點名的三種鍵形式：巢狀建構體（`user[address][city]`）、
+	// 索引式切片（`tags[0]`/`tags[1]`）與 `map[string]string` 欄位（`user[meta][*]`），
+	// 全部透過 `BindToPtr` 這條實際的映射管線，而不只是各自獨立的內部函式。
+	data := map[string][]string{
+		"user[name]":          {"alice"},
+		"user[tags][0]":       {"x"},
+		"user[tags][1]":       {"y"},
+		"user[address][city]": {"nyc"},
+		"user[meta][role]":    {"admin"},
+		"user[meta][plan]":    {"pro"},
+	}
+
+	ptr := &nestedFormPayload{}
+	if err := BindToPtr(ptr, data, fieldTagForm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ptr.User.Name != "alice" {
+		t.Errorf("User.Name = %q, want %q", ptr.User.Name, "alice")
+	}
+	if !reflect.DeepEqual(ptr.User.Tags, []string{"x", "y"}) {
+		t.Errorf("User.Tags = %v, want %v", ptr.User.Tags, []string{"x", "y"})
+	}
+	if ptr.User.Address.City != "nyc" {
+		t.Errorf("User.Address.City = %q, want %q", ptr.User.Address.City, "nyc")
+	}
+	want := map[string]string{"role": "admin", "plan": "pro"}
+	if !reflect.DeepEqual(ptr.User.Meta, want) {
+		t.Errorf("User.Meta = %v, want %v", ptr.User.Meta, want)
+	}
+}
+
+func TestNormalizeKey(t *testing.T) {
+	cases := map[string]string{
+		"User-Agent":      "useragent",
+		"user_name":       "username",
+		"profile_picture": "profilepicture",
+	}
+	for in, want := range cases {
+		if got := normalizeKey(in); got != want {
+			t.Errorf("normalizeKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package binding
+
+import (
+	"mime/multipart"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// bindUnmarshalerType 是 `BindUnmarshaler` 介面的反射型態，用於在建立欄位計劃時判斷欄位是否實作該介面。
+var bindUnmarshalerType = reflect.TypeOf((*BindUnmarshaler)(nil)).Elem()
+
+// fileHeaderType 是 `multipart.FileHeader` 的反射型態，用於在建立欄位計劃時判斷欄位是否為上傳檔案。
+var fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+// typePlan 是一個建構體型態所對應的欄位計劃，會在首次映射時建立並快取於 `planCache`，
+// 讓之後相同型態的映射都能夠重複利用同一份反射資訊。
+type typePlan struct {
+	typ    reflect.Type
+	fields []fieldPlan
+}
+
+// fieldPlan 是單一欄位的映射計劃，預先保存了映射時所需要的所有反射資訊，
+// 讓 `bindStruct` 不需要在每次映射請求時都重新呼叫 `Tag.Get` 或判斷欄位種類。
+type fieldPlan struct {
+	// index 是該欄位在建構體中的索引。
+	index int
+	// kind 是該欄位的型態種類。
+	kind reflect.Kind
+	// rules 是從 `binding` 標籤解析出來的驗證規則（例如 `required`、`min=3`）。
+	rules []tagRule
+	// unmarshaler 表示該欄位（或其指針指向的型態）是否實作 `BindUnmarshaler` 介面。
+	unmarshaler bool
+	// dive 表示是否應該將資料以遞迴方式映射進此巢狀建構體，
+	// 凡是未實作 `BindUnmarshaler` 的建構體欄位（不論是否帶有標籤）都會設為 `true`。
+	dive bool
+	// fileHeader 表示該欄位型態是否為 `*multipart.FileHeader`，用於映射單一上傳檔案。
+	fileHeader bool
+	// fileHeaderSlice 表示該欄位型態是否為 `[]*multipart.FileHeader`，用於映射多個上傳檔案。
+	fileHeaderSlice bool
+	// isMap 表示該欄位是否為 `map[string]T` 型態，會以 `prefix[*]` 這樣的鍵填入映射內容。
+	isMap bool
+	// sub 是巢狀建構體的欄位計劃，僅在 `dive` 為 `true` 時才會建立。
+	sub *typePlan
+	// fallback 是沒有標籤時退而求其次使用的欄位名稱（已轉換為小寫）。
+	fallback string
+	// tags 保存了各個映射來源（`form`、`uri`、`param`、`header`）所解析出的標籤原始值。
+	tags map[string]string
+}
+
+// resolveName 會依照目前使用的標籤 `tag`，解析出此欄位應該對應的資料來源名稱。
+// 若回傳的名稱為 `-` 表示此欄位應該被忽略；若 `dive` 為 `true` 則表示應該遞迴映射至 `sub`。
+func (f *fieldPlan) resolveName(tag string) (name string, dive bool) {
+	raw := f.tags[tag]
+	if raw == "" && tag == fieldTagURI {
+		raw = f.tags[fieldTagParam]
+	}
+	if raw == "-" {
+		return "-", false
+	}
+	if raw == "" {
+		if f.dive {
+			return "", true
+		}
+		return f.fallback, false
+	}
+	return raw, false
+}
+
+// planCache 是以 `reflect.Type` 為鍵的欄位計劃快取，避免每次映射請求都重新反射建構體欄位。
+var planCache sync.Map
+
+// cachedFields 會回傳一個建構體型態的欄位計劃，若快取中不存在則會建立一份新的計劃並快取起來。
+func cachedFields(typ reflect.Type) *typePlan {
+	if cached, ok := planCache.Load(typ); ok {
+		return cached.(*typePlan)
+	}
+	plan := buildFieldPlan(typ)
+	actual, _ := planCache.LoadOrStore(typ, plan)
+	return actual.(*typePlan)
+}
+
+// buildFieldPlan 會走訪建構體的每一個欄位，建立對應的欄位計劃。
+func buildFieldPlan(typ reflect.Type) *typePlan {
+	plan := &typePlan{typ: typ}
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		// 無法匯出的欄位永遠無法被設值，因此不需要納入計劃中。
+		if structField.PkgPath != "" {
+			continue
+		}
+
+		kind := structField.Type.Kind()
+		unmarshaler := false
+		if kind == reflect.Ptr {
+			unmarshaler = typeIsUnmarshaler(structField.Type.Elem())
+		} else {
+			unmarshaler = typeIsUnmarshaler(structField.Type)
+		}
+
+		fileHeader := kind == reflect.Ptr && structField.Type.Elem() == fileHeaderType
+		fileHeaderSlice := kind == reflect.Slice && structField.Type.Elem().Kind() == reflect.Ptr && structField.Type.Elem().Elem() == fileHeaderType
+		isMap := kind == reflect.Map && structField.Type.Key().Kind() == reflect.String
+
+		field := fieldPlan{
+			index:           i,
+			kind:            kind,
+			rules:           parseRules(structField.Tag.Get(fieldTagBinding)),
+			unmarshaler:     unmarshaler,
+			fallback:        strings.ToLower(structField.Name),
+			fileHeader:      fileHeader,
+			fileHeaderSlice: fileHeaderSlice,
+			isMap:           isMap,
+			tags: map[string]string{
+				fieldTagForm:   structField.Tag.Get(fieldTagForm),
+				fieldTagURI:    structField.Tag.Get(fieldTagURI),
+				fieldTagParam:  structField.Tag.Get(fieldTagParam),
+				fieldTagHeader: structField.Tag.Get(fieldTagHeader),
+			},
+		}
+		if !unmarshaler && kind == reflect.Struct {
+			field.dive = true
+			field.sub = cachedFields(structField.Type)
+		}
+		plan.fields = append(plan.fields, field)
+	}
+	return plan
+}
+
+// typeIsUnmarshaler 會表示一個型態的指針是否實作 `BindUnmarshaler` 介面。
+func typeIsUnmarshaler(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(bindUnmarshalerType)
+}
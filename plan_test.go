@@ -0,0 +1,40 @@
+package binding
+
+import (
+	"mime/multipart"
+	"reflect"
+	"testing"
+)
+
+type namePayload struct {
+	UserName string
+}
+
+func TestBindStructUntaggedFieldMatchesSnakeCaseKey(t *testing.T) {
+	ptr, err := Bind(namePayload{}, map[string][]string{"user_name": {"alice"}}, fieldTagForm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ptr.(*namePayload).UserName; got != "alice" {
+		t.Fatalf("UserName = %q, want %q", got, "alice")
+	}
+}
+
+func TestCachedFieldsReused(t *testing.T) {
+	typ := reflect.TypeOf(namePayload{})
+	plan1 := cachedFields(typ)
+	plan2 := cachedFields(typ)
+	if plan1 != plan2 {
+		t.Fatalf("expected cachedFields to return the same cached plan for the same type")
+	}
+}
+
+func TestConvertFileKeysMatchesResolvedName(t *testing.T) {
+	files := map[string][]*multipart.FileHeader{
+		"profile_picture": {{Filename: "avatar.png"}},
+	}
+	converted := convertFileKeys(files)
+	if _, ok := converted[normalizeKey("profile_picture")]; !ok {
+		t.Fatalf("expected convertFileKeys to normalize %q the same way resolveName's tag is matched", "profile_picture")
+	}
+}
@@ -0,0 +1,26 @@
+package binding
+
+import (
+	"io"
+	"mime/multipart"
+	"os"
+)
+
+// SaveUploadedFile 會將上傳檔案 `fh` 的內容寫入至 `dst` 所指定的路徑，
+// 讓處理函式能夠直接保存已映射的上傳檔案，而不需要另外透過 `net/http` 或 `os` 自行處理。
+func SaveUploadedFile(fh *multipart.FileHeader, dst string) error {
+	src, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, src)
+	return err
+}
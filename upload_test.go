@@ -0,0 +1,132 @@
+package binding
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildMultipartFileHeaders 會在記憶體中組出一個帶有單一檔案欄位的 multipart 表單，
+// 並解析回 `*multipart.FileHeader`，讓測試不需要真正發送 HTTP 請求即可取得可用的上傳檔案。
+func buildMultipartFileHeaders(t *testing.T, field, filename string, content []byte) []*multipart.FileHeader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatalf("unexpected error creating form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("unexpected error writing form file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error closing writer: %v", err)
+	}
+
+	reader := multipart.NewReader(&buf, writer.Boundary())
+	form, err := reader.ReadForm(defaultMemory)
+	if err != nil {
+		t.Fatalf("unexpected error reading form: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File[field]
+}
+
+func TestBindToPtrSingleFileHeaderField(t *testing.T) {
+	type payload struct {
+		Avatar *multipart.FileHeader `form:"avatar"`
+	}
+
+	files := map[string][]*multipart.FileHeader{
+		"avatar": buildMultipartFileHeaders(t, "avatar", "avatar.png", []byte("fake-image-bytes")),
+	}
+
+	ptr := &payload{}
+	if err := bindToPtr(ptr, map[string][]string{}, files, fieldTagForm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ptr.Avatar == nil {
+		t.Fatalf("expected Avatar to be populated")
+	}
+	if ptr.Avatar.Filename != "avatar.png" {
+		t.Fatalf("Filename = %q, want %q", ptr.Avatar.Filename, "avatar.png")
+	}
+}
+
+func TestBindToPtrFileHeaderSliceField(t *testing.T) {
+	type payload struct {
+		Attachments []*multipart.FileHeader `form:"attachments"`
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for i, name := range []string{"a.txt", "b.txt"} {
+		part, err := writer.CreateFormFile("attachments", name)
+		if err != nil {
+			t.Fatalf("unexpected error creating form file %d: %v", i, err)
+		}
+		if _, err := part.Write([]byte(name)); err != nil {
+			t.Fatalf("unexpected error writing form file %d: %v", i, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error closing writer: %v", err)
+	}
+	reader := multipart.NewReader(&buf, writer.Boundary())
+	form, err := reader.ReadForm(defaultMemory)
+	if err != nil {
+		t.Fatalf("unexpected error reading form: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	ptr := &payload{}
+	if err := bindToPtr(ptr, map[string][]string{}, form.File, fieldTagForm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ptr.Attachments) != 2 {
+		t.Fatalf("expected 2 attachments, got %d", len(ptr.Attachments))
+	}
+}
+
+func TestBindToPtrRequiredFileHeaderAbsent(t *testing.T) {
+	type payload struct {
+		Avatar *multipart.FileHeader `form:"avatar" binding:"required"`
+	}
+
+	err := bindToPtr(&payload{}, map[string][]string{}, nil, fieldTagForm)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Rule != "required" {
+		t.Fatalf("expected a single required validation error for an absent upload, got %v", err)
+	}
+}
+
+func TestSaveUploadedFile(t *testing.T) {
+	headers := buildMultipartFileHeaders(t, "file", "report.txt", []byte("hello from upload"))
+	if len(headers) != 1 {
+		t.Fatalf("expected exactly one file header, got %d", len(headers))
+	}
+
+	dst := filepath.Join(t.TempDir(), "saved.txt")
+	if err := SaveUploadedFile(headers[0], dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saved, err := os.Open(dst)
+	if err != nil {
+		t.Fatalf("unexpected error opening saved file: %v", err)
+	}
+	defer saved.Close()
+
+	content, err := io.ReadAll(saved)
+	if err != nil {
+		t.Fatalf("unexpected error reading saved file: %v", err)
+	}
+	if string(content) != "hello from upload" {
+		t.Fatalf("saved content = %q, want %q", content, "hello from upload")
+	}
+}
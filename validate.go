@@ -0,0 +1,326 @@
+package binding
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FieldError 表示單一欄位未能通過某一條驗證規則。
+type FieldError struct {
+	// Field 是映射時所使用的資料欄位名稱。
+	Field string
+	// Rule 是未能通過的規則名稱，例如 `required`、`min`。
+	Rule string
+	// Message 是該規則失敗時的說明訊息。
+	Message string
+}
+
+// Error 實作了 `error` 介面。
+func (e FieldError) Error() string {
+	return fmt.Sprintf("binding: field %q failed on the %q rule: %s", e.Field, e.Rule, e.Message)
+}
+
+// ValidationErrors 匯集了一次映射中所有未能通過驗證的欄位，取代了過去遇到第一個錯誤就中止的行為。
+type ValidationErrors []FieldError
+
+// Error 實作了 `error` 介面，會將所有欄位錯誤以分號連接成單一訊息。
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// RuleFunc 是一個驗證規則函式，`field` 是已經完成映射的欄位值，`param` 是規則在標籤中附帶的參數
+// （例如 `min=3` 的 `3`），若欄位未能通過驗證則應該回傳一個描述原因的錯誤。
+type RuleFunc func(field reflect.Value, param string) error
+
+var (
+	// ruleMu 用於保護 `ruleRegistry` 不會在 `RegisterRule` 與驗證執行時發生資料競爭。
+	ruleMu sync.RWMutex
+	// ruleRegistry 是規則名稱所對應的驗證函式，可透過 `RegisterRule` 註冊自訂規則或覆蓋內建規則。
+	ruleRegistry = map[string]RuleFunc{
+		"required": ruleRequired,
+		"min":      ruleMin,
+		"max":      ruleMax,
+		"gt":       ruleGT,
+		"lt":       ruleLT,
+		"oneof":    ruleOneof,
+		"len":      ruleLen,
+		"email":    ruleEmail,
+		"regex":    ruleRegex,
+		"url":      ruleURL,
+	}
+)
+
+// RegisterRule 能夠註冊或覆蓋一條 `binding` 標籤所使用的驗證規則。
+func RegisterRule(name string, fn RuleFunc) {
+	ruleMu.Lock()
+	defer ruleMu.Unlock()
+	ruleRegistry[name] = fn
+}
+
+// tagRule 是一條從 `binding` 標籤解析出來的驗證規則。
+type tagRule struct {
+	name  string
+	param string
+}
+
+// parseRules 會將 `binding:"required,min=3,max=32"` 這樣的標籤值解析成一組驗證規則。
+func parseRules(tag string) []tagRule {
+	if tag == "" {
+		return nil
+	}
+	segments := strings.Split(tag, ",")
+	rules := make([]tagRule, 0, len(segments))
+	for _, segment := range segments {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(segment, "=")
+		rules = append(rules, tagRule{name: name, param: param})
+	}
+	return rules
+}
+
+// runRules 會依序執行欄位計劃中的所有驗證規則，並將失敗的結果附加至 `errs`。
+func runRules(structField reflect.Value, field *fieldPlan, inputFieldName string, errs *ValidationErrors) {
+	if len(field.rules) == 0 {
+		return
+	}
+	ruleMu.RLock()
+	defer ruleMu.RUnlock()
+	for _, rule := range field.rules {
+		fn, ok := ruleRegistry[rule.name]
+		if !ok {
+			continue
+		}
+		if err := fn(structField, rule.param); err != nil {
+			*errs = append(*errs, FieldError{Field: inputFieldName, Rule: rule.name, Message: err.Error()})
+		}
+	}
+}
+
+// runRequiredRule 只會執行欄位計劃中的 `required` 規則，並將失敗的結果附加至 `errs`。
+// 當資料來源中根本不存在對應的鍵時會呼叫此函式，因為其餘規則（例如 `min`、`oneof`）
+// 是針對「已提供但不符合條件」的值設計的，對一個從未被提交、本來就允許缺席的欄位套用這些規則並不合理。
+func runRequiredRule(structField reflect.Value, field *fieldPlan, inputFieldName string, errs *ValidationErrors) {
+	for _, rule := range field.rules {
+		if rule.name != "required" {
+			continue
+		}
+		if err := ruleRequired(structField, rule.param); err != nil {
+			*errs = append(*errs, FieldError{Field: inputFieldName, Rule: rule.name, Message: err.Error()})
+		}
+	}
+}
+
+// ruleRequired 會驗證欄位是否仍帶有零值。
+func ruleRequired(field reflect.Value, _ string) error {
+	if field.CanInterface() && isZeroOfUnderlyingType(field.Interface()) {
+		return ErrRequired
+	}
+	return nil
+}
+
+// ruleMin 會驗證數值欄位的最小值，或是字串、切片欄位的最小長度。
+func ruleMin(field reflect.Value, param string) error {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return err
+	}
+	length, isLength, value, isNumeric := comparableValue(field)
+	switch {
+	case isNumeric:
+		if value < n {
+			return fmt.Errorf("must be at least %s", param)
+		}
+	case isLength:
+		if float64(length) < n {
+			return fmt.Errorf("must have a length of at least %s", param)
+		}
+	}
+	return nil
+}
+
+// ruleMax 會驗證數值欄位的最大值，或是字串、切片欄位的最大長度。
+func ruleMax(field reflect.Value, param string) error {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return err
+	}
+	length, isLength, value, isNumeric := comparableValue(field)
+	switch {
+	case isNumeric:
+		if value > n {
+			return fmt.Errorf("must be at most %s", param)
+		}
+	case isLength:
+		if float64(length) > n {
+			return fmt.Errorf("must have a length of at most %s", param)
+		}
+	}
+	return nil
+}
+
+// ruleGT 會驗證數值欄位是否大於給定的參數，或是字串、切片欄位的長度是否大於給定的參數。
+func ruleGT(field reflect.Value, param string) error {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return err
+	}
+	length, isLength, value, isNumeric := comparableValue(field)
+	switch {
+	case isNumeric:
+		if !(value > n) {
+			return fmt.Errorf("must be greater than %s", param)
+		}
+	case isLength:
+		if !(float64(length) > n) {
+			return fmt.Errorf("must have a length greater than %s", param)
+		}
+	}
+	return nil
+}
+
+// ruleLT 會驗證數值欄位是否小於給定的參數，或是字串、切片欄位的長度是否小於給定的參數。
+func ruleLT(field reflect.Value, param string) error {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return err
+	}
+	length, isLength, value, isNumeric := comparableValue(field)
+	switch {
+	case isNumeric:
+		if !(value < n) {
+			return fmt.Errorf("must be less than %s", param)
+		}
+	case isLength:
+		if !(float64(length) < n) {
+			return fmt.Errorf("must have a length less than %s", param)
+		}
+	}
+	return nil
+}
+
+// ruleLen 會驗證字串、切片或映射欄位是否符合給定的長度。
+func ruleLen(field reflect.Value, param string) error {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return err
+	}
+	length, ok, _, _ := comparableValue(field)
+	if ok && length != n {
+		return fmt.Errorf("must have a length of %s", param)
+	}
+	return nil
+}
+
+// ruleOneof 會驗證欄位的值是否屬於空白分隔的候選清單之一。
+func ruleOneof(field reflect.Value, param string) error {
+	value := stringifyValue(field)
+	for _, option := range strings.Fields(param) {
+		if option == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of [%s]", param)
+}
+
+// ruleEmail 會驗證字串欄位是否為合法的電子郵件地址格式。
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+func ruleEmail(field reflect.Value, _ string) error {
+	if field.Kind() != reflect.String {
+		return nil
+	}
+	if !emailPattern.MatchString(field.String()) {
+		return fmt.Errorf("must be a valid email address")
+	}
+	return nil
+}
+
+// ruleURL 會驗證字串欄位是否為具有 scheme 與 host 的合法網址。
+func ruleURL(field reflect.Value, _ string) error {
+	if field.Kind() != reflect.String {
+		return nil
+	}
+	u, err := url.ParseRequestURI(field.String())
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("must be a valid URL")
+	}
+	return nil
+}
+
+// regexCache 重複利用已編譯的正規表達式，避免 `regex` 規則在每次驗證時都重新編譯樣式。
+var regexCache sync.Map
+
+// ruleRegex 會驗證字串欄位是否符合給定的正規表達式樣式。
+func ruleRegex(field reflect.Value, param string) error {
+	if field.Kind() != reflect.String {
+		return nil
+	}
+	var pattern *regexp.Regexp
+	if cached, ok := regexCache.Load(param); ok {
+		pattern = cached.(*regexp.Regexp)
+	} else {
+		compiled, err := regexp.Compile(param)
+		if err != nil {
+			return err
+		}
+		actual, _ := regexCache.LoadOrStore(param, compiled)
+		pattern = actual.(*regexp.Regexp)
+	}
+	if !pattern.MatchString(field.String()) {
+		return fmt.Errorf("must match pattern %s", param)
+	}
+	return nil
+}
+
+// comparableValue 會嘗試取出一個欄位可用於長度比較（字串、切片、映射）或數值比較（int/uint/float）的值。
+func comparableValue(field reflect.Value) (length int, isLength bool, value float64, isNumeric bool) {
+	switch field.Kind() {
+	case reflect.String:
+		return len(field.String()), true, 0, false
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return field.Len(), true, 0, false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return 0, false, float64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 0, false, float64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return 0, false, field.Float(), true
+	default:
+		return 0, false, 0, false
+	}
+}
+
+// stringifyValue 會將一個欄位值轉換為字串，用於 `oneof` 這類需要比對字面值的規則。
+func stringifyValue(field reflect.Value) string {
+	if field.CanInterface() {
+		if stringer, ok := field.Interface().(fmt.Stringer); ok {
+			return stringer.String()
+		}
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool())
+	default:
+		return fmt.Sprintf("%v", field.Interface())
+	}
+}
@@ -0,0 +1,93 @@
+package binding
+
+import "testing"
+
+func TestParseRules(t *testing.T) {
+	rules := parseRules("required,min=3,max=32")
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(rules))
+	}
+	if rules[0].name != "required" || rules[0].param != "" {
+		t.Errorf("unexpected first rule: %+v", rules[0])
+	}
+	if rules[1].name != "min" || rules[1].param != "3" {
+		t.Errorf("unexpected second rule: %+v", rules[1])
+	}
+}
+
+type requiredPayload struct {
+	Name string `form:"name" binding:"required"`
+}
+
+func TestBindToPtrRequiredFieldAbsent(t *testing.T) {
+	err := BindToPtr(&requiredPayload{}, map[string][]string{}, fieldTagForm)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Rule != "required" {
+		t.Fatalf("expected a single required validation error for an absent field, got %v", err)
+	}
+}
+
+func TestBindToPtrRequiredFieldPresentButEmpty(t *testing.T) {
+	err := BindToPtr(&requiredPayload{}, map[string][]string{"name": {""}}, fieldTagForm)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Rule != "required" {
+		t.Fatalf("expected a single required validation error for an empty field, got %v", err)
+	}
+}
+
+func TestBindToPtrRequiredFieldSatisfied(t *testing.T) {
+	err := BindToPtr(&requiredPayload{}, map[string][]string{"name": {"alice"}}, fieldTagForm)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestBindToPtrOptionalRuleIgnoredWhenFieldAbsent(t *testing.T) {
+	type payload struct {
+		Age int `form:"age" binding:"min=18"`
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{}, fieldTagForm); err != nil {
+		t.Fatalf("expected no error when an optional, non-required field is entirely absent, got %v", err)
+	}
+}
+
+func TestRuleMinMax(t *testing.T) {
+	type payload struct {
+		Age int `form:"age" binding:"min=18,max=65"`
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{"age": {"10"}}, fieldTagForm); err == nil {
+		t.Fatalf("expected a min validation error")
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{"age": {"30"}}, fieldTagForm); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestRuleGTLTNumeric(t *testing.T) {
+	type payload struct {
+		Age int `form:"age" binding:"gt=3,lt=10"`
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{"age": {"3"}}, fieldTagForm); err == nil {
+		t.Fatalf("expected a gt validation error")
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{"age": {"10"}}, fieldTagForm); err == nil {
+		t.Fatalf("expected a lt validation error")
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{"age": {"5"}}, fieldTagForm); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestRuleGTLTStringLength(t *testing.T) {
+	// comparableValue 支援字串長度比較，gt/lt 必須和 min/max 一樣涵蓋這個分支，
+	// 否則像 `binding:"gt=3"` 這樣的規則會在字串欄位上被靜默忽略。
+	type payload struct {
+		Name string `form:"name" binding:"gt=3"`
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{"name": {"ab"}}, fieldTagForm); err == nil {
+		t.Fatalf("expected a gt validation error for a 2-character string")
+	}
+	if err := BindToPtr(&payload{}, map[string][]string{"name": {"abcd"}}, fieldTagForm); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}